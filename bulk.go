@@ -0,0 +1,284 @@
+// Retrying and bulk send helpers for SimpleEmailAPI SDK.
+//
+// These build on the ErrorCode taxonomy in errors.go to turn it into actual
+// runtime policy: rate limits are retried with backoff, and hard usage
+// limits stop a batch early instead of burning through it call by call.
+package emailapi
+
+import (
+	"context"
+	"math/rand"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	v1 "github.com/emailapi/sdk-go/gen/v1"
+)
+
+// RetryPolicy controls how SendWithRetry and SendBulk retry rate-limited
+// sends.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first.
+	// Default is 3.
+	MaxAttempts int
+
+	// BaseDelay is the backoff delay used before the first retry when the
+	// server doesn't tell us how long to wait. It doubles on each
+	// subsequent retry. Default is 500ms.
+	BaseDelay time.Duration
+
+	// MaxDelay caps the backoff delay. Default is 10s.
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy returns the RetryPolicy used when one isn't specified.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    10 * time.Second,
+	}
+}
+
+// normalize fills in defaults for any unset fields.
+func (p RetryPolicy) normalize() RetryPolicy {
+	def := DefaultRetryPolicy()
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = def.MaxAttempts
+	}
+	if p.BaseDelay <= 0 {
+		p.BaseDelay = def.BaseDelay
+	}
+	if p.MaxDelay <= 0 {
+		p.MaxDelay = def.MaxDelay
+	}
+	return p
+}
+
+// SendWithRetry sends an email, automatically retrying with jittered
+// backoff when the API responds with ErrCodeRateLimited. It honors a
+// server-provided wait time over its own backoff when one is present in
+// the error's Metadata (see MetadataRetryAfterMs). Errors other than
+// ErrCodeRateLimited are returned immediately without retrying.
+func (c *Client) SendWithRetry(ctx context.Context, req *v1.SendEmailRequest, policy RetryPolicy) (*v1.SendEmailResponse, error) {
+	resp, _, err := c.sendWithRetry(ctx, req, policy)
+	return resp, err
+}
+
+// sendWithRetry is SendWithRetry plus the attempt count, which SendBulk
+// needs to classify an item as retried.
+func (c *Client) sendWithRetry(ctx context.Context, req *v1.SendEmailRequest, policy RetryPolicy) (*v1.SendEmailResponse, int, error) {
+	policy = policy.normalize()
+
+	delay := policy.BaseDelay
+	var lastErr error
+
+	for attempt := 1; attempt <= policy.MaxAttempts; attempt++ {
+		resp, err := c.Send(ctx, req)
+		if err == nil {
+			return resp.Msg, attempt, nil
+		}
+		lastErr = err
+
+		e := ParseError(err)
+		if e == nil || !e.Is(ErrCodeRateLimited) || attempt == policy.MaxAttempts {
+			return nil, attempt, err
+		}
+
+		wait := retryAfter(e.Metadata)
+		if wait <= 0 {
+			wait = jitter(delay)
+			delay *= 2
+			if delay > policy.MaxDelay {
+				delay = policy.MaxDelay
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, attempt, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+
+	return nil, policy.MaxAttempts, lastErr
+}
+
+// retryAfter reads MetadataRetryAfterMs off meta, returning 0 if it's
+// absent or invalid. The server's wait is a mandated minimum, so it's only
+// ever extended for jitter, never shortened like our own guessed backoff.
+func retryAfter(meta map[string]string) time.Duration {
+	raw, ok := meta[MetadataRetryAfterMs]
+	if !ok {
+		return 0
+	}
+	ms, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || ms <= 0 {
+		return 0
+	}
+	return extendJitter(time.Duration(ms) * time.Millisecond)
+}
+
+// jitter returns a random duration in [d/2, d], so concurrent callers
+// retrying after the same rate-limit error don't all wake up at once. It's
+// only for our own guessed backoff, where waiting a bit less is harmless.
+func jitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// extendJitter returns a random duration in [d, d+d/2), staggering
+// concurrent callers without ever waiting less than d. Use this for a
+// server-mandated wait, which must never be shortened.
+func extendJitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	half := d / 2
+	if half <= 0 {
+		return d
+	}
+	return d + time.Duration(rand.Int63n(int64(half)))
+}
+
+// BulkOptions configures SendBulk.
+type BulkOptions struct {
+	// Concurrency is the number of SendEmail calls in flight at once.
+	// Default is 5.
+	Concurrency int
+
+	// RetryPolicy controls retry behavior for rate-limited sends. Default
+	// is DefaultRetryPolicy().
+	RetryPolicy RetryPolicy
+}
+
+// BulkItemResult is the outcome of one request within a SendBulk call.
+type BulkItemResult struct {
+	// Index is the item's position in the slice passed to SendBulk.
+	Index int
+
+	// Request is the original request for this item.
+	Request *v1.SendEmailRequest
+
+	// Response is the successful response, if any.
+	Response *v1.SendEmailResponse
+
+	// Err is the final error for this item, if it never succeeded. It is
+	// also set on abandoned items to the error that stopped the batch.
+	Err error
+
+	// Attempts is how many times this item was sent. 0 for abandoned items.
+	Attempts int
+
+	// Abandoned is true if the batch stopped early and this item was never
+	// attempted.
+	Abandoned bool
+}
+
+// BulkResult summarizes a SendBulk call. An item can appear in both
+// Retried and Succeeded (or Failed) when it needed more than one attempt
+// before settling.
+type BulkResult struct {
+	// Succeeded holds items whose send ultimately completed successfully.
+	Succeeded []*BulkItemResult
+
+	// Retried holds items that needed more than one attempt.
+	Retried []*BulkItemResult
+
+	// Failed holds items that were attempted but never succeeded.
+	Failed []*BulkItemResult
+
+	// Abandoned holds items that were never attempted because the batch
+	// stopped early, e.g. after ErrCodeDailyLimitExceeded.
+	Abandoned []*BulkItemResult
+}
+
+// SendBulk sends many emails concurrently, retrying rate-limited sends per
+// opts.RetryPolicy. If any send fails with ErrCodeDailyLimitExceeded or
+// ErrCodeMonthlyCreditsExhausted, the whole batch stops: in-flight sends
+// are allowed to finish, but no new sends are started and the remaining
+// items are reported as Abandoned.
+func (c *Client) SendBulk(ctx context.Context, reqs []*v1.SendEmailRequest, opts BulkOptions) *BulkResult {
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 5
+	}
+	policy := opts.RetryPolicy.normalize()
+
+	items := make([]*BulkItemResult, len(reqs))
+	for i, req := range reqs {
+		items[i] = &BulkItemResult{Index: i, Request: req}
+	}
+
+	var (
+		wg       sync.WaitGroup
+		sem      = make(chan struct{}, opts.Concurrency)
+		stopped  int32
+		stopErr  error
+		stopOnce sync.Once
+	)
+
+	for _, item := range items {
+		if atomic.LoadInt32(&stopped) == 1 {
+			item.Abandoned = true
+			item.Err = stopErr
+			continue
+		}
+
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(item *BulkItemResult) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			// stopped may have been set while this item was parked on sem;
+			// re-check before dispatching so "no new sends are started"
+			// holds even for items that were already queued for a slot.
+			if atomic.LoadInt32(&stopped) == 1 {
+				item.Abandoned = true
+				item.Err = stopErr
+				return
+			}
+
+			resp, attempts, err := c.sendWithRetry(ctx, item.Request, policy)
+			item.Attempts = attempts
+			if err != nil {
+				item.Err = err
+				if e := ParseError(err); e != nil &&
+					(e.Is(ErrCodeDailyLimitExceeded) || e.Is(ErrCodeMonthlyCreditsExhausted)) {
+					stopOnce.Do(func() {
+						stopErr = err
+						atomic.StoreInt32(&stopped, 1)
+					})
+				}
+				return
+			}
+			item.Response = resp
+		}(item)
+	}
+
+	wg.Wait()
+
+	result := &BulkResult{}
+	for _, item := range items {
+		switch {
+		case item.Abandoned:
+			result.Abandoned = append(result.Abandoned, item)
+		case item.Err != nil:
+			if item.Attempts > 1 {
+				result.Retried = append(result.Retried, item)
+			}
+			result.Failed = append(result.Failed, item)
+		default:
+			if item.Attempts > 1 {
+				result.Retried = append(result.Retried, item)
+			}
+			result.Succeeded = append(result.Succeeded, item)
+		}
+	}
+
+	return result
+}
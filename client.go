@@ -29,10 +29,10 @@
 // # Event Streaming
 //
 //	cancel := client.OnReceive(ctx, emailapi.EventHandlers{
-//	    OnDelivered: func(e *v1.EmailDeliveredEvent) {
+//	    OnDelivered: func(e *v1.EmailDeliveredEvent, ack *emailapi.EventAck) {
 //	        log.Println("Delivered to:", e.Recipients)
 //	    },
-//	    OnBounced: func(e *v1.EmailBouncedEvent) {
+//	    OnBounced: func(e *v1.EmailBouncedEvent, ack *emailapi.EventAck) {
 //	        log.Println("Bounced:", e.BounceType)
 //	    },
 //	})
@@ -62,10 +62,16 @@ type Client struct {
 	// Domains provides access to domain management operations.
 	Domains v1connect.DomainServiceClient
 
+	// Webhooks provides access to webhook enrollment operations (registering
+	// endpoint URLs and rotating signing secrets). See the webhook
+	// subpackage for verifying and handling the callbacks themselves.
+	Webhooks v1connect.WebhookServiceClient
+
 	// Internal state
-	apiKey  string
-	baseURL string
-	http    connect.HTTPClient
+	apiKey      string
+	baseURL     string
+	http        connect.HTTPClient
+	cursorStore CursorStore
 }
 
 // ClientOption configures the client.
@@ -85,6 +91,16 @@ func WithHTTPClient(client connect.HTTPClient) ClientOption {
 	}
 }
 
+// WithCursorStore enables durable resume: OnReceive loads the last processed
+// event from store on startup and saves the cursor to it after each
+// successful ack flush, so a restarted process resumes instead of starting
+// from "now".
+func WithCursorStore(store CursorStore) ClientOption {
+	return func(c *Client) {
+		c.cursorStore = store
+	}
+}
+
 // NewClient creates a new SimpleEmailAPI client.
 //
 // Example:
@@ -124,6 +140,7 @@ func NewClient(apiKey string, opts ...ClientOption) *Client {
 
 	c.Emails = v1connect.NewEmailServiceClient(c.http, c.baseURL, clientOpts...)
 	c.Domains = v1connect.NewDomainServiceClient(c.http, c.baseURL, clientOpts...)
+	c.Webhooks = v1connect.NewWebhookServiceClient(c.http, c.baseURL, clientOpts...)
 
 	return c
 }
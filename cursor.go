@@ -0,0 +1,74 @@
+// Durable cursor storage for resumable event streaming.
+//
+// This file lets a stream resume from the last processed event after a
+// process restart, instead of starting from "now" and relying entirely on
+// the server to replay unacked events.
+package emailapi
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// CursorStore persists the ID of the last resolved event (Ack'd, Nak'd, or
+// Term'd) so OnReceive can resume a stream from where it left off after a
+// restart.
+type CursorStore interface {
+	// Load returns the last saved cursor, or "" if none has been saved yet.
+	Load(ctx context.Context) (string, error)
+
+	// Save persists eventID as the new cursor.
+	Save(ctx context.Context, eventID string) error
+}
+
+// FileCursorStore is a CursorStore backed by a local file. It is safe for
+// concurrent use.
+type FileCursorStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileCursorStore creates a FileCursorStore that persists the cursor to
+// path. The file and any missing parent directories are created on first
+// Save.
+func NewFileCursorStore(path string) *FileCursorStore {
+	return &FileCursorStore{path: path}
+}
+
+// Load implements CursorStore.
+func (f *FileCursorStore) Load(ctx context.Context) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	data, err := os.ReadFile(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(data)), nil
+}
+
+// Save implements CursorStore. It writes via a temp file and renames over
+// the destination so a crash mid-write cannot corrupt the cursor.
+func (f *FileCursorStore) Save(ctx context.Context, eventID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(f.path), 0o700); err != nil {
+		return err
+	}
+
+	tmp := f.path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(eventID), 0o600); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, f.path)
+}
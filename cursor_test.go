@@ -0,0 +1,43 @@
+package emailapi
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileCursorStoreRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "nested", "cursor")
+	store := NewFileCursorStore(path)
+
+	got, err := store.Load(ctx)
+	if err != nil {
+		t.Fatalf("Load() on unwritten store: %v", err)
+	}
+	if got != "" {
+		t.Fatalf("Load() on unwritten store = %q, want empty", got)
+	}
+
+	if err := store.Save(ctx, "evt_1"); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	got, err = store.Load(ctx)
+	if err != nil {
+		t.Fatalf("Load() after Save: %v", err)
+	}
+	if got != "evt_1" {
+		t.Fatalf("Load() = %q, want %q", got, "evt_1")
+	}
+
+	if err := store.Save(ctx, "evt_2"); err != nil {
+		t.Fatalf("Save() overwrite error: %v", err)
+	}
+	got, err = store.Load(ctx)
+	if err != nil {
+		t.Fatalf("Load() after second Save: %v", err)
+	}
+	if got != "evt_2" {
+		t.Fatalf("Load() = %q, want %q", got, "evt_2")
+	}
+}
@@ -92,6 +92,14 @@ const (
 	ErrCodeServiceUnavailable    ErrorCode = 902
 )
 
+// Metadata keys the server sets on Error.Metadata for programmatic use.
+const (
+	// MetadataRetryAfterMs is set on ErrCodeRateLimited errors: the number
+	// of milliseconds the caller should wait before retrying, as a decimal
+	// string.
+	MetadataRetryAfterMs = "retry_after_ms"
+)
+
 // Error category constants for IsCategory checks.
 const (
 	CategoryAuth       = "auth"
@@ -9,22 +9,44 @@
 //	defer cancel()
 //
 //	client.OnReceive(ctx, emailapi.EventHandlers{
-//	    OnDelivered: func(e *v1.EmailDeliveredEvent) {
+//	    OnDelivered: func(e *v1.EmailDeliveredEvent, ack *emailapi.EventAck) {
 //	        log.Println("Delivered to:", e.Recipients)
 //	    },
-//	    OnBounced: func(e *v1.EmailBouncedEvent) {
+//	    OnBounced: func(e *v1.EmailBouncedEvent, ack *emailapi.EventAck) {
 //	        log.Println("Bounced:", e.BounceType)
 //	    },
 //	    OnError: func(err error) {
 //	        log.Println("Stream error:", err)
 //	    },
 //	})
+//
+// # Manual Acknowledgment
+//
+// Set AckMode to AckModeManual to take control of redelivery. Each callback
+// receives an *EventAck alongside the event; call exactly one of Ack, Nak,
+// or Term on it once the event has been handled:
+//
+//	client.OnReceive(ctx, emailapi.EventHandlers{
+//	    AckMode: emailapi.AckModeManual,
+//	    AckWait: 30 * time.Second,
+//	    OnDelivered: func(e *v1.EmailDeliveredEvent, ack *emailapi.EventAck) {
+//	        if err := save(e); err != nil {
+//	            ack.Nak(5 * time.Second) // retry shortly
+//	            return
+//	        }
+//	        ack.Ack()
+//	    },
+//	})
+//
+// If a handler returns without resolving the ack within AckWait, the SDK
+// logs a warning and issues an automatic Nak on the handler's behalf.
 package emailapi
 
 import (
 	"context"
 	"log"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"connectrpc.com/connect"
@@ -52,34 +74,43 @@ const (
 	// Ack batching settings
 	ackBatchSize       = 10
 	ackFlushIntervalMs = 1000 * time.Millisecond
+
+	// defaultAckWait is how long a manual-ack handler has to call Ack, Nak,
+	// or Term before the SDK automatically Naks the event on its behalf.
+	defaultAckWait = 30 * time.Second
 )
 
 // EventHandlers contains callbacks for different email events.
 // Define only the callbacks you care about.
+//
+// Every callback receives an *EventAck alongside the event. In AckModeAuto
+// the ack is resolved automatically after the callback returns and calling
+// its methods is unnecessary; in AckModeManual the callback must resolve it
+// by calling Ack, Nak, or Term.
 type EventHandlers struct {
 	// OnSent is called when an email is accepted for delivery.
-	OnSent func(*v1.EmailSentEvent)
+	OnSent func(*v1.EmailSentEvent, *EventAck)
 
 	// OnDelivered is called when an email is successfully delivered.
-	OnDelivered func(*v1.EmailDeliveredEvent)
+	OnDelivered func(*v1.EmailDeliveredEvent, *EventAck)
 
 	// OnBounced is called when an email bounces.
-	OnBounced func(*v1.EmailBouncedEvent)
+	OnBounced func(*v1.EmailBouncedEvent, *EventAck)
 
 	// OnComplained is called when a recipient marks the email as spam.
-	OnComplained func(*v1.EmailComplainedEvent)
+	OnComplained func(*v1.EmailComplainedEvent, *EventAck)
 
 	// OnRejected is called when SES rejects the email.
-	OnRejected func(*v1.EmailRejectedEvent)
+	OnRejected func(*v1.EmailRejectedEvent, *EventAck)
 
 	// OnDelayed is called when email delivery is delayed.
-	OnDelayed func(*v1.EmailDelayedEvent)
+	OnDelayed func(*v1.EmailDelayedEvent, *EventAck)
 
 	// OnReplied is called when a reply is received.
-	OnReplied func(*v1.EmailRepliedEvent)
+	OnReplied func(*v1.EmailRepliedEvent, *EventAck)
 
 	// OnFailed is called when email sending fails permanently.
-	OnFailed func(*v1.EmailFailedEvent)
+	OnFailed func(*v1.EmailFailedEvent, *EventAck)
 
 	// OnError is called when an error occurs in the stream.
 	OnError func(error)
@@ -89,6 +120,28 @@ type EventHandlers struct {
 
 	// BatchSize is the number of events to buffer per batch. Default is 10.
 	BatchSize int32
+
+	// AckWait is how long a manual-ack handler has to call Ack, Nak, or Term
+	// before the SDK automatically Naks the event and logs a warning.
+	// Only applies when AckMode is AckModeManual. Default is 30s.
+	AckWait time.Duration
+
+	// DurableName identifies this subscription to the server so it can
+	// resume delivery from the position associated with the name across
+	// reconnects, independent of the client's own CursorStore.
+	DurableName string
+
+	// Concurrency is the number of workers dispatching events concurrently.
+	// Within a pool, events for the same email are always routed to the same
+	// worker, so per-email ordering is preserved. Default is 1 (sequential
+	// dispatch). Event types given their own pool via EventTypeConcurrency
+	// are not ordered relative to same-email events in other pools.
+	Concurrency int
+
+	// EventTypeConcurrency overrides Concurrency for specific event types,
+	// giving them their own dedicated worker pool so a slow handler for one
+	// event type can't starve delivery of the others.
+	EventTypeConcurrency map[v1.EventType]int
 }
 
 // eventStreamer manages a streaming connection with reconnection logic.
@@ -99,8 +152,22 @@ type eventStreamer struct {
 
 	// Ack batching
 	pendingAcks   []string
+	pendingCursor string
 	pendingAcksMu sync.Mutex
 	ackTimer      *time.Timer
+
+	// Cursor watermark: pendingCursor only ever advances to the highest
+	// receive-order sequence number that has been contiguously resolved
+	// (by Ack, Nak, or Term), even though workers can resolve events out of
+	// order. cursorPending holds resolved-but-not-yet-contiguous entries,
+	// keyed by seq. Guarded by pendingAcksMu.
+	nextSeq         uint64
+	cursorWatermark uint64
+	cursorPending   map[uint64]string
+
+	// Dispatch workers
+	defaultPool *workerPool
+	typePools   map[v1.EventType]*workerPool
 }
 
 // OnReceive starts streaming events with typed callbacks.
@@ -121,23 +188,30 @@ func (c *Client) OnReceive(ctx context.Context, handlers EventHandlers) {
 	if handlers.BatchSize <= 0 {
 		handlers.BatchSize = 10
 	}
+	if handlers.AckWait <= 0 {
+		handlers.AckWait = defaultAckWait
+	}
 
 	streamer := &eventStreamer{
-		client:      c,
-		handlers:    handlers,
-		ctx:         ctx,
-		pendingAcks: make([]string, 0, ackBatchSize),
+		client:        c,
+		handlers:      handlers,
+		ctx:           ctx,
+		pendingAcks:   make([]string, 0, ackBatchSize),
+		cursorPending: make(map[uint64]string),
 	}
 
 	go streamer.run()
 }
 
 func (s *eventStreamer) run() {
+	s.initWorkers()
+
 	currentDelay := initialDelay
 
 	for {
 		select {
 		case <-s.ctx.Done():
+			s.stopWorkers()
 			s.flushAcks()
 			return
 		default:
@@ -147,6 +221,7 @@ func (s *eventStreamer) run() {
 
 		select {
 		case <-s.ctx.Done():
+			s.stopWorkers()
 			s.flushAcks()
 			return
 		default:
@@ -174,9 +249,21 @@ func (s *eventStreamer) run() {
 }
 
 func (s *eventStreamer) stream() error {
+	var startAfter string
+	if s.client.cursorStore != nil {
+		cursor, err := s.client.cursorStore.Load(s.ctx)
+		if err != nil {
+			log.Printf("emailapi: failed to load cursor, resuming from server default: %v", err)
+		} else {
+			startAfter = cursor
+		}
+	}
+
 	stream, err := s.client.Emails.StreamEvents(s.ctx, connect.NewRequest(&v1.StreamEventsRequest{
-		EventTypes: []v1.EventType{}, // All events
-		BatchSize:  s.handlers.BatchSize,
+		EventTypes:        []v1.EventType{}, // All events
+		BatchSize:         s.handlers.BatchSize,
+		StartAfterEventId: startAfter,
+		DurableName:       s.handlers.DurableName,
 	}))
 	if err != nil {
 		return err
@@ -197,23 +284,50 @@ func (s *eventStreamer) stream() error {
 			continue
 		}
 
-		// Dispatch to appropriate handler
-		s.dispatchEvent(event)
-
-		// Auto-ack if enabled
-		if s.handlers.AckMode == AckModeAuto && event.Id != "" {
-			s.queueAck(event.Id)
+		// Assign this event's receive-order sequence number before handing
+		// it off, so out-of-order completion across workers can still be
+		// reduced back to a contiguous cursor (see queueAck). Events with no
+		// ID can never be resolved against the cursor (Ack/Nak/Term all
+		// no-op without an ID), so they don't consume a sequence number --
+		// doing so would leave a permanent gap that stalls the watermark.
+		var seq uint64
+		if event.GetId() != "" {
+			seq = s.nextSeq
+			s.nextSeq++
 		}
+
+		// Route to the event type's pool, then to a worker in it by hashing
+		// the email ID, so all events for one email within a pool are
+		// dispatched in order on the same worker. submit
+		// also watches s.ctx so a stuck handler holding a full worker queue
+		// can't prevent the stream from being cancelled.
+		s.poolFor(event.Type).submit(s.ctx, event.GetEmailId(), event, seq)
 	}
 
 	return stream.Err()
 }
 
-func (s *eventStreamer) dispatchEvent(event *v1.Event) {
+// dispatchEvent invokes the handler registered for event's payload type and
+// returns the EventAck it was given, so the caller can drive auto-ack. seq
+// is event's receive-order sequence number, carried by the ack so cursor
+// advancement can be reduced back to contiguous order.
+func (s *eventStreamer) dispatchEvent(event *v1.Event, seq uint64) *EventAck {
+	ack := newEventAck(s, event.GetId(), seq)
+	DispatchEvent(s.handlers, event, ack)
+	return ack
+}
+
+// DispatchEvent invokes whichever callback in handlers matches event's
+// payload type, passing ack through as the callback's second argument.
+// It is exported so alternative transports (e.g. the webhook package) can
+// reuse the same dispatch logic as the streaming client. ack may be nil for
+// transports with no ack protocol of their own; EventAck's methods are
+// no-ops on a nil receiver.
+func DispatchEvent(handlers EventHandlers, event *v1.Event, ack *EventAck) {
 	defer func() {
 		if r := recover(); r != nil {
-			if s.handlers.OnError != nil {
-				s.handlers.OnError(&Error{
+			if handlers.OnError != nil {
+				handlers.OnError(&Error{
 					Code:    ErrCodeInternal,
 					Message: "panic in event handler",
 				})
@@ -224,45 +338,98 @@ func (s *eventStreamer) dispatchEvent(event *v1.Event) {
 
 	switch payload := event.Payload.(type) {
 	case *v1.Event_EmailSent:
-		if s.handlers.OnSent != nil {
-			s.handlers.OnSent(payload.EmailSent)
+		if handlers.OnSent != nil {
+			handlers.OnSent(payload.EmailSent, ack)
 		}
 	case *v1.Event_EmailDelivered:
-		if s.handlers.OnDelivered != nil {
-			s.handlers.OnDelivered(payload.EmailDelivered)
+		if handlers.OnDelivered != nil {
+			handlers.OnDelivered(payload.EmailDelivered, ack)
 		}
 	case *v1.Event_EmailBounced:
-		if s.handlers.OnBounced != nil {
-			s.handlers.OnBounced(payload.EmailBounced)
+		if handlers.OnBounced != nil {
+			handlers.OnBounced(payload.EmailBounced, ack)
 		}
 	case *v1.Event_EmailComplained:
-		if s.handlers.OnComplained != nil {
-			s.handlers.OnComplained(payload.EmailComplained)
+		if handlers.OnComplained != nil {
+			handlers.OnComplained(payload.EmailComplained, ack)
 		}
 	case *v1.Event_EmailRejected:
-		if s.handlers.OnRejected != nil {
-			s.handlers.OnRejected(payload.EmailRejected)
+		if handlers.OnRejected != nil {
+			handlers.OnRejected(payload.EmailRejected, ack)
 		}
 	case *v1.Event_EmailDelayed:
-		if s.handlers.OnDelayed != nil {
-			s.handlers.OnDelayed(payload.EmailDelayed)
+		if handlers.OnDelayed != nil {
+			handlers.OnDelayed(payload.EmailDelayed, ack)
 		}
 	case *v1.Event_EmailReplied:
-		if s.handlers.OnReplied != nil {
-			s.handlers.OnReplied(payload.EmailReplied)
+		if handlers.OnReplied != nil {
+			handlers.OnReplied(payload.EmailReplied, ack)
 		}
 	case *v1.Event_EmailFailed:
-		if s.handlers.OnFailed != nil {
-			s.handlers.OnFailed(payload.EmailFailed)
+		if handlers.OnFailed != nil {
+			handlers.OnFailed(payload.EmailFailed, ack)
 		}
 	}
 }
 
-func (s *eventStreamer) queueAck(eventID string) {
+// initWorkers starts the default worker pool plus one dedicated pool per
+// event type listed in EventTypeConcurrency. Called once before the
+// reconnect loop; the pools live for the lifetime of the streamer, not per
+// connection attempt.
+func (s *eventStreamer) initWorkers() {
+	concurrency := s.handlers.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	s.defaultPool = newWorkerPool(concurrency, s.processEvent)
+
+	if len(s.handlers.EventTypeConcurrency) == 0 {
+		return
+	}
+	s.typePools = make(map[v1.EventType]*workerPool, len(s.handlers.EventTypeConcurrency))
+	for eventType, n := range s.handlers.EventTypeConcurrency {
+		if n <= 0 {
+			continue
+		}
+		s.typePools[eventType] = newWorkerPool(n, s.processEvent)
+	}
+}
+
+// poolFor returns the worker pool that should handle eventType, falling
+// back to the default pool if no dedicated pool was configured for it.
+func (s *eventStreamer) poolFor(eventType v1.EventType) *workerPool {
+	if p, ok := s.typePools[eventType]; ok {
+		return p
+	}
+	return s.defaultPool
+}
+
+// stopWorkers closes every pool's input channels and waits for in-flight
+// handlers to finish, so flushAcks (called right after) never acks an event
+// whose handler hasn't actually run yet.
+func (s *eventStreamer) stopWorkers() {
+	s.defaultPool.closeAndWait()
+	for _, p := range s.typePools {
+		p.closeAndWait()
+	}
+}
+
+// processEvent dispatches a single event and, in auto-ack mode, resolves
+// its ack once the handler returns. It is the work function run by every
+// pool worker.
+func (s *eventStreamer) processEvent(event *v1.Event, seq uint64) {
+	ack := s.dispatchEvent(event, seq)
+	if s.handlers.AckMode == AckModeAuto && event.Id != "" {
+		ack.Ack()
+	}
+}
+
+func (s *eventStreamer) queueAck(eventID string, seq uint64) {
 	s.pendingAcksMu.Lock()
 	defer s.pendingAcksMu.Unlock()
 
 	s.pendingAcks = append(s.pendingAcks, eventID)
+	s.advanceCursorLocked(seq, eventID)
 
 	// Flush immediately if batch is full
 	if len(s.pendingAcks) >= ackBatchSize {
@@ -270,7 +437,42 @@ func (s *eventStreamer) queueAck(eventID string) {
 		return
 	}
 
-	// Schedule flush if not already scheduled
+	s.scheduleFlushLocked()
+}
+
+// markCursorResolved advances the cursor watermark for an event that was
+// Nak'd or Term'd. Both outcomes mean the handler ran and the server has
+// its own record of what happens next (redelivery or dead-lettering), so
+// it's safe to consider the event resolved for restart-resume purposes
+// even though it wasn't Ack'd.
+func (s *eventStreamer) markCursorResolved(eventID string, seq uint64) {
+	s.pendingAcksMu.Lock()
+	defer s.pendingAcksMu.Unlock()
+
+	s.advanceCursorLocked(seq, eventID)
+	s.scheduleFlushLocked()
+}
+
+// advanceCursorLocked records that seq/eventID has been resolved, then
+// advances pendingCursor through as long a contiguous run from the current
+// watermark as is available. Callers must hold pendingAcksMu.
+func (s *eventStreamer) advanceCursorLocked(seq uint64, eventID string) {
+	s.cursorPending[seq] = eventID
+
+	for {
+		id, ok := s.cursorPending[s.cursorWatermark]
+		if !ok {
+			return
+		}
+		s.pendingCursor = id
+		delete(s.cursorPending, s.cursorWatermark)
+		s.cursorWatermark++
+	}
+}
+
+// scheduleFlushLocked arms the batch flush timer if one isn't already
+// running. Callers must hold pendingAcksMu.
+func (s *eventStreamer) scheduleFlushLocked() {
 	if s.ackTimer == nil {
 		s.ackTimer = time.AfterFunc(ackFlushIntervalMs, func() {
 			s.pendingAcksMu.Lock()
@@ -287,7 +489,7 @@ func (s *eventStreamer) flushAcks() {
 }
 
 func (s *eventStreamer) flushAcksLocked() {
-	if len(s.pendingAcks) == 0 {
+	if len(s.pendingAcks) == 0 && s.pendingCursor == "" {
 		return
 	}
 
@@ -298,18 +500,158 @@ func (s *eventStreamer) flushAcksLocked() {
 	}
 
 	idsToAck := s.pendingAcks
+	cursor := s.pendingCursor
 	s.pendingAcks = make([]string, 0, ackBatchSize)
+	s.pendingCursor = ""
 
 	// Fire and forget - server will replay unacked events on reconnect
 	go func() {
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
 
-		_, err := s.client.Emails.AckEvents(ctx, connect.NewRequest(&v1.AckEventsRequest{
-			EventIds: idsToAck,
+		if len(idsToAck) > 0 {
+			_, err := s.client.Emails.AckEvents(ctx, connect.NewRequest(&v1.AckEventsRequest{
+				EventIds: idsToAck,
+			}))
+			if err != nil {
+				log.Printf("emailapi: failed to ack events: %v", err)
+				return
+			}
+		}
+
+		if s.client.cursorStore != nil && cursor != "" {
+			if err := s.client.cursorStore.Save(ctx, cursor); err != nil {
+				log.Printf("emailapi: failed to save cursor: %v", err)
+			}
+		}
+	}()
+}
+
+// sendNak asks the server to redeliver eventID after delay. Like
+// flushAcksLocked, this is fire-and-forget: the event is not counted
+// against the auto-ack batch either way.
+func (s *eventStreamer) sendNak(eventID string, delay time.Duration) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		_, err := s.client.Emails.NakEvents(ctx, connect.NewRequest(&v1.NakEventsRequest{
+			EventIds: []string{eventID},
+			DelayMs:  delay.Milliseconds(),
+		}))
+		if err != nil {
+			log.Printf("emailapi: failed to nak event %s: %v", eventID, err)
+		}
+	}()
+}
+
+// sendTerm permanently rejects eventID so the server dead-letters it instead
+// of redelivering.
+func (s *eventStreamer) sendTerm(eventID string) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		_, err := s.client.Emails.TermEvents(ctx, connect.NewRequest(&v1.TermEventsRequest{
+			EventIds: []string{eventID},
 		}))
 		if err != nil {
-			log.Printf("emailapi: failed to ack events: %v", err)
+			log.Printf("emailapi: failed to term event %s: %v", eventID, err)
 		}
 	}()
 }
+
+// ackState tracks whether an EventAck has been resolved yet, guarding
+// against double-resolution from a racing handler and the AckWait timer.
+type ackState = int32
+
+const (
+	ackPending ackState = iota
+	ackResolved
+)
+
+// EventAck is a handle for acknowledging a single delivered event. Exactly
+// one of Ack, Nak, or Term should be called per event; subsequent calls on
+// the same EventAck are no-ops. A nil *EventAck is valid and all of its
+// methods are no-ops, which lets transports without an ack protocol (e.g.
+// the webhook package) pass nil to DispatchEvent.
+type EventAck struct {
+	eventID   string
+	seq       uint64
+	streamer  *eventStreamer
+	state     int32
+	waitTimer *time.Timer
+}
+
+// newEventAck creates the ack handle for eventID and, in manual ack mode,
+// arms the AckWait deadline that auto-Naks the event if the handler never
+// resolves it. seq is the event's receive-order sequence number, used to
+// keep cursor advancement contiguous despite out-of-order resolution.
+func newEventAck(s *eventStreamer, eventID string, seq uint64) *EventAck {
+	ack := &EventAck{eventID: eventID, seq: seq, streamer: s}
+
+	if s.handlers.AckMode == AckModeManual && eventID != "" {
+		ack.waitTimer = time.AfterFunc(s.handlers.AckWait, func() {
+			if !ack.resolve() {
+				return
+			}
+			log.Printf("emailapi: handler for event %s did not ack within %s, issuing automatic Nak", eventID, s.handlers.AckWait)
+			s.sendNak(eventID, 0)
+			s.markCursorResolved(eventID, seq)
+		})
+	}
+
+	return ack
+}
+
+// resolve marks the ack as resolved, returning false if it was already
+// resolved (by the handler or by the AckWait timer).
+func (a *EventAck) resolve() bool {
+	return atomic.CompareAndSwapInt32(&a.state, ackPending, ackResolved)
+}
+
+func (a *EventAck) stopWait() {
+	if a.waitTimer != nil {
+		a.waitTimer.Stop()
+	}
+}
+
+// Ack acknowledges the event, feeding it into the batched AckEvents flush.
+func (a *EventAck) Ack() {
+	if a == nil {
+		return
+	}
+	a.stopWait()
+	if a.eventID == "" || !a.resolve() {
+		return
+	}
+	a.streamer.queueAck(a.eventID, a.seq)
+}
+
+// Nak negatively-acknowledges the event, asking the server to redeliver it
+// after delay. The event is not counted against the auto-ack batch.
+func (a *EventAck) Nak(delay time.Duration) {
+	if a == nil {
+		return
+	}
+	a.stopWait()
+	if a.eventID == "" || !a.resolve() {
+		return
+	}
+	a.streamer.sendNak(a.eventID, delay)
+	a.streamer.markCursorResolved(a.eventID, a.seq)
+}
+
+// Term permanently rejects the event so it is dead-lettered instead of
+// redelivered.
+func (a *EventAck) Term() {
+	if a == nil {
+		return
+	}
+	a.stopWait()
+	if a.eventID == "" || !a.resolve() {
+		return
+	}
+	a.streamer.sendTerm(a.eventID)
+	a.streamer.markCursorResolved(a.eventID, a.seq)
+}
@@ -0,0 +1,172 @@
+// Package webhook receives SimpleEmailAPI events over signed HTTP callbacks,
+// as an alternative to the long-lived Connect stream in the parent package.
+// It's meant for deployments that can't hold a streaming connection open
+// (serverless, short-lived containers, environments behind a proxy that
+// kills idle connections).
+//
+// Handlers are shared with the streaming client: build the same
+// emailapi.EventHandlers and mount it either way.
+//
+//	mux.Handle("/webhooks/simpleemailapi", webhook.Handler(secret, emailapi.EventHandlers{
+//	    OnDelivered: func(e *v1.EmailDeliveredEvent, _ *emailapi.EventAck) {
+//	        log.Println("Delivered to:", e.Recipients)
+//	    },
+//	}))
+//
+// Ack/Nak/Term on the *emailapi.EventAck passed to handlers are no-ops in
+// this package: there's no ack protocol over a webhook, only the HTTP
+// response code the server sees for that single delivery.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	emailapi "github.com/emailapi/sdk-go"
+	v1 "github.com/emailapi/sdk-go/gen/v1"
+)
+
+const (
+	// SignatureHeader carries the hex-encoded HMAC-SHA256 signature of the
+	// request, computed as described in Verify.
+	SignatureHeader = "X-SimpleEmailAPI-Signature"
+
+	// TimestampHeader carries the Unix timestamp (seconds) the payload was
+	// signed at, used for replay protection.
+	TimestampHeader = "X-SimpleEmailAPI-Timestamp"
+
+	// DefaultTolerance is how far a request's timestamp may drift from now
+	// before it's rejected as a replay.
+	DefaultTolerance = 5 * time.Minute
+)
+
+// SeenStore deduplicates webhook deliveries by event ID. The server retries
+// a webhook delivery until it gets a 2xx response, so the same event can
+// arrive more than once.
+type SeenStore interface {
+	// Seen records id as processed and reports whether it had already been
+	// recorded, i.e. whether this delivery is a duplicate.
+	Seen(id string) (bool, error)
+}
+
+// handlerConfig holds Handler's optional settings.
+type handlerConfig struct {
+	tolerance time.Duration
+	seenStore SeenStore
+}
+
+// HandlerOption configures Handler.
+type HandlerOption func(*handlerConfig)
+
+// WithTolerance overrides DefaultTolerance for replay protection.
+func WithTolerance(d time.Duration) HandlerOption {
+	return func(cfg *handlerConfig) {
+		cfg.tolerance = d
+	}
+}
+
+// WithSeenStore enables idempotency: deliveries whose event ID was already
+// seen are acknowledged with 200 but not dispatched again.
+func WithSeenStore(store SeenStore) HandlerOption {
+	return func(cfg *handlerConfig) {
+		cfg.seenStore = store
+	}
+}
+
+// Handler returns an http.Handler that verifies, deduplicates, and
+// dispatches incoming webhook deliveries to handlers using the same
+// dispatch logic as the streaming client. It responds 401 on a bad
+// signature, 408 on a stale or missing timestamp, 200 on success
+// (including duplicates, so the server doesn't retry them), and 500 if the
+// request body or event payload can't be read.
+func Handler(secret string, handlers emailapi.EventHandlers, opts ...HandlerOption) http.Handler {
+	cfg := &handlerConfig{tolerance: DefaultTolerance}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "failed to read request body", http.StatusInternalServerError)
+			return
+		}
+
+		err = Verify(secret, r.Header.Get(TimestampHeader), r.Header.Get(SignatureHeader), body, cfg.tolerance)
+		if err != nil {
+			var sdkErr *emailapi.Error
+			if errors.As(err, &sdkErr) && sdkErr.Is(emailapi.ErrCodeWebhookSecretInvalid) {
+				http.Error(w, sdkErr.Message, http.StatusUnauthorized)
+				return
+			}
+			http.Error(w, err.Error(), http.StatusRequestTimeout)
+			return
+		}
+
+		var event v1.Event
+		if err := protojson.Unmarshal(body, &event); err != nil {
+			http.Error(w, "invalid event payload", http.StatusInternalServerError)
+			return
+		}
+
+		if cfg.seenStore != nil && event.GetId() != "" {
+			seen, err := cfg.seenStore.Seen(event.GetId())
+			if err != nil {
+				http.Error(w, "failed to check idempotency", http.StatusInternalServerError)
+				return
+			}
+			if seen {
+				w.WriteHeader(http.StatusOK)
+				return
+			}
+		}
+
+		emailapi.DispatchEvent(handlers, &event, nil)
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+// Verify checks that signature is the hex-encoded HMAC-SHA256 of
+// "<timestamp>.<body>" keyed by secret, and that timestamp is within
+// tolerance of now. tolerance <= 0 means DefaultTolerance.
+//
+// It returns an *emailapi.Error with ErrCodeWebhookSecretInvalid on a
+// signature mismatch, so code with its own HTTP stack can reuse the same
+// error taxonomy as the rest of the SDK.
+func Verify(secret, timestamp, signature string, body []byte, tolerance time.Duration) error {
+	if tolerance <= 0 {
+		tolerance = DefaultTolerance
+	}
+
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("webhook: invalid %s header: %w", TimestampHeader, err)
+	}
+	if age := time.Since(time.Unix(ts, 0)); age < -tolerance || age > tolerance {
+		return fmt.Errorf("webhook: timestamp outside %s tolerance window", tolerance)
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	expected := hex.EncodeToString(mac.Sum(nil))
+
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return &emailapi.Error{
+			Code:    emailapi.ErrCodeWebhookSecretInvalid,
+			Message: "webhook: signature does not match payload",
+		}
+	}
+
+	return nil
+}
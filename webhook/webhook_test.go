@@ -0,0 +1,77 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	emailapi "github.com/emailapi/sdk-go"
+)
+
+func sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerify(t *testing.T) {
+	const secret = "whsec_test"
+	body := []byte(`{"id":"evt_1"}`)
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	stale := strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10)
+
+	tests := []struct {
+		name      string
+		timestamp string
+		signature string
+		wantErr   bool
+		wantCode  emailapi.ErrorCode
+	}{
+		{
+			name:      "valid signature",
+			timestamp: now,
+			signature: sign(secret, now, body),
+			wantErr:   false,
+		},
+		{
+			name:      "bad signature",
+			timestamp: now,
+			signature: sign("wrong-secret", now, body),
+			wantErr:   true,
+			wantCode:  emailapi.ErrCodeWebhookSecretInvalid,
+		},
+		{
+			name:      "stale timestamp",
+			timestamp: stale,
+			signature: sign(secret, stale, body),
+			wantErr:   true,
+		},
+		{
+			name:      "malformed timestamp header",
+			timestamp: "not-a-timestamp",
+			signature: sign(secret, now, body),
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Verify(secret, tt.timestamp, tt.signature, body, DefaultTolerance)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("Verify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && tt.wantCode != emailapi.ErrCodeUnspecified {
+				var sdkErr *emailapi.Error
+				if !errors.As(err, &sdkErr) || !sdkErr.Is(tt.wantCode) {
+					t.Fatalf("Verify() error = %v, want code %v", err, tt.wantCode)
+				}
+			}
+		})
+	}
+}
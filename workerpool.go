@@ -0,0 +1,92 @@
+// Bounded worker pool for concurrent event dispatch.
+//
+// A slow handler for one event shouldn't stall delivery of the rest, but
+// events for the same email still need to be processed in order. workerPool
+// hashes a routing key to a fixed worker so ordering is preserved per key
+// while unrelated events run concurrently. Each item also carries the
+// receive-order sequence number it was assigned, so callers can track
+// completion order separately from dispatch order (see eventStreamer's
+// cursor watermark in events.go).
+package emailapi
+
+import (
+	"context"
+	"hash/fnv"
+	"sync"
+
+	v1 "github.com/emailapi/sdk-go/gen/v1"
+)
+
+// workerQueueSize is the per-worker channel buffer. It bounds how many
+// events can be queued ahead of a slow worker before submit blocks,
+// providing backpressure on the receive loop.
+const workerQueueSize = 64
+
+// workItem is a unit of work routed through a workerPool.
+type workItem struct {
+	event *v1.Event
+	seq   uint64
+}
+
+// workerPool is a fixed-size set of goroutines, each fed by its own bounded
+// channel, that run fn for every event routed to it.
+type workerPool struct {
+	chans []chan workItem
+	wg    sync.WaitGroup
+}
+
+// newWorkerPool starts n workers running fn and returns the pool. n is
+// clamped to at least 1.
+func newWorkerPool(n int, fn func(*v1.Event, uint64)) *workerPool {
+	if n < 1 {
+		n = 1
+	}
+
+	p := &workerPool{chans: make([]chan workItem, n)}
+	for i := range p.chans {
+		ch := make(chan workItem, workerQueueSize)
+		p.chans[i] = ch
+
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			for item := range ch {
+				fn(item.event, item.seq)
+			}
+		}()
+	}
+
+	return p
+}
+
+// submit routes event to the worker assigned to key, blocking if that
+// worker's queue is full until either the item is accepted or ctx is done.
+// All events sharing a key are processed in submission order by the same
+// worker.
+func (p *workerPool) submit(ctx context.Context, key string, event *v1.Event, seq uint64) {
+	ch := p.chans[workerIndex(key, len(p.chans))]
+	select {
+	case ch <- workItem{event: event, seq: seq}:
+	case <-ctx.Done():
+	}
+}
+
+// closeAndWait closes every worker's input channel and blocks until all
+// workers have drained their queues and returned.
+func (p *workerPool) closeAndWait() {
+	for _, ch := range p.chans {
+		close(ch)
+	}
+	p.wg.Wait()
+}
+
+// workerIndex deterministically maps key to a worker in [0, n).
+func workerIndex(key string, n int) int {
+	if n <= 1 || key == "" {
+		return 0
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return int(h.Sum32() % uint32(n))
+}